@@ -0,0 +1,100 @@
+package got
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveChecksumInline(t *testing.T) {
+	d := &Download{Checksum: "sha256:abcd"}
+
+	algo, expected, err := d.resolveChecksum()
+	if err != nil {
+		t.Fatalf("resolveChecksum: %v", err)
+	}
+	if algo != "sha256" || expected != "abcd" {
+		t.Fatalf("got algo=%q expected=%q, want sha256/abcd", algo, expected)
+	}
+}
+
+func TestResolveChecksumMalformed(t *testing.T) {
+	d := &Download{Checksum: "nocolonhere"}
+
+	if _, _, err := d.resolveChecksum(); err == nil {
+		t.Fatal("expected an error for a checksum with no algo:hex separator")
+	}
+}
+
+func TestFetchChecksumCoreutilsFormat(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "deadbeefdeadbeefdeadbeefdeadbeef  other.tar.gz\nabc123abc123abc123abc123abc123ab  *file.tar.gz\n")
+	}))
+	defer srv.Close()
+
+	d := &Download{filename: "/tmp/downloads/file.tar.gz"}
+
+	_, expected, err := d.fetchChecksum(srv.URL)
+	if err != nil {
+		t.Fatalf("fetchChecksum: %v", err)
+	}
+	if expected != "abc123abc123abc123abc123abc123ab" {
+		t.Fatalf("got expected=%q, want the entry matching file.tar.gz's basename", expected)
+	}
+}
+
+func TestFetchChecksumBareHash(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "  abc123abc123abc123abc123abc123ab  \n")
+	}))
+	defer srv.Close()
+
+	d := &Download{filename: "/tmp/downloads/file.tar.gz"}
+
+	_, expected, err := d.fetchChecksum(srv.URL)
+	if err != nil {
+		t.Fatalf("fetchChecksum: %v", err)
+	}
+	if expected != "abc123abc123abc123abc123abc123ab" {
+		t.Fatalf("got expected=%q, want the lone bare hash", expected)
+	}
+}
+
+func TestFetchChecksumNoMatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "deadbeefdeadbeefdeadbeefdeadbeef  unrelated.tar.gz\n")
+	}))
+	defer srv.Close()
+
+	d := &Download{filename: "/tmp/downloads/file.tar.gz"}
+
+	if _, _, err := d.fetchChecksum(srv.URL); err == nil {
+		t.Fatal("expected an error when no line matches the destination file's basename")
+	}
+}
+
+func TestAlgoForHexLen(t *testing.T) {
+	cases := []struct {
+		n    int
+		want string
+	}{
+		{32, "md5"},
+		{40, "sha1"},
+		{64, "sha256"},
+		{128, "sha512"},
+		{7, ""},
+	}
+
+	for _, c := range cases {
+		if got := algoForHexLen(c.n); got != c.want {
+			t.Errorf("algoForHexLen(%d) = %q, want %q", c.n, got, c.want)
+		}
+	}
+}
+
+func TestNewHashUnsupported(t *testing.T) {
+	if _, err := newHash("crc32"); err == nil {
+		t.Fatal("expected an error for an unsupported algorithm")
+	}
+}
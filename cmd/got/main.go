@@ -9,7 +9,9 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 
 	"github.com/pokeguys/got"
@@ -76,6 +78,50 @@ func main() {
 				Usage:   `Set user agent for got HTTP requests.`,
 				Aliases: []string{"u"},
 			},
+			&cli.StringFlag{
+				Name:    "checksum",
+				Usage:   `Verify the download against a "algo:hex" checksum (e.g. sha256:abcd...) or a URL to a checksums file.`,
+				Aliases: []string{"k"},
+			},
+			&cli.BoolFlag{
+				Name:  "continue",
+				Usage: `Resume an interrupted download from its ".got" state file instead of starting over. Note: "-c" is already taken by --concurrency, so this has no short alias.`,
+			},
+			&cli.BoolFlag{
+				Name:    "extract",
+				Usage:   `Decompress/extract the download once it's complete (gzip, bzip2, xz, zstd, tar, tar.gz, tar.bz2, tar.xz, zip), auto-detected unless --format is set.`,
+				Aliases: []string{"x"},
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: `Force the archive/compression format used by --extract, e.g. "tar.gz", instead of auto-detecting it.`,
+			},
+			&cli.IntFlag{
+				Name:  "strip-components",
+				Usage: `Remove the first N path elements of each archive entry when extracting, like tar's --strip-components.`,
+			},
+			&cli.StringSliceFlag{
+				Name:  "mirror",
+				Usage: `Extra source URL(s) for the download, repeatable. got picks the fastest responders and spreads chunk requests across them.`,
+			},
+			&cli.StringFlag{
+				Name:  "limit-rate",
+				Usage: `Cap the aggregate download bandwidth, e.g. "2M" or "512K".`,
+			},
+			&cli.IntFlag{
+				Name:  "max-retries",
+				Usage: `Number of times to retry a chunk after a transient failure (connection reset, 5xx, 408, 429).`,
+				Value: got.DefaultRetryPolicy.MaxRetries,
+			},
+			&cli.DurationFlag{
+				Name:  "retry-wait",
+				Usage: `Base delay the exponential retry backoff scales from, e.g. "500ms".`,
+				Value: got.DefaultRetryPolicy.Wait,
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: `Per-chunk request timeout, e.g. "30s". 0 disables it.`,
+			},
 		},
 		Version: version,
 		Authors: []*cli.Author{
@@ -98,12 +144,30 @@ func run(ctx context.Context, c *cli.Context) error {
 	var (
 		g *got.Got                 = got.NewWithContext(ctx)
 		p *progressbar.ProgressBar = progressbar.New(0)
+
+		mirrorStatsMu sync.Mutex
+		mirrorStats   []got.MirrorStat
 	)
 
+	// Mirror stats, reported alongside progress when Mirrors is set.
+	// MirrorStatsFunc fires from every in-flight chunk goroutine while
+	// ProgressFunc fires from an independent ticker goroutine, so the
+	// shared slice needs a lock.
+	g.MirrorStatsFunc = func(d *got.Download, stats []got.MirrorStat) {
+		mirrorStatsMu.Lock()
+		mirrorStats = stats
+		mirrorStatsMu.Unlock()
+	}
+
 	// Progress func.
 	g.ProgressFunc = func(d *got.Download) {
+		mirrorStatsMu.Lock()
+		stats := mirrorStats
+		mirrorStatsMu.Unlock()
+
 		p.ChangeMax(int(d.TotalSize()))
 		p.Add(int(d.Size()))
+		p.Describe(formatRate(d.Rate()) + formatMirrorStats(stats))
 	}
 
 	info, err := os.Stdin.Stat()
@@ -158,7 +222,7 @@ func run(ctx context.Context, c *cli.Context) error {
 	// Download from args.
 	for _, url := range c.Args().Slice() {
 
-		if err = download(ctx, c, g, url); err != nil {
+		if err = download(ctx, c, g, url, c.StringSlice("mirror")); err != nil {
 			return err
 		}
 
@@ -169,43 +233,161 @@ func run(ctx context.Context, c *cli.Context) error {
 	return nil
 }
 
-func multiDownload(ctx context.Context, c *cli.Context, g *got.Got, scanner *bufio.Scanner) error {
+// batchEntry is one URL from a --file/stdin batch, plus the mirrors
+// declared for it on a following "mirrors: url1, url2" line.
+type batchEntry struct {
+	url     string
+	mirrors []string
+}
+
+func parseBatch(scanner *bufio.Scanner) []batchEntry {
+	var entries []batchEntry
+
 	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
 
-		url := strings.TrimSpace(scanner.Text())
+		if rest, ok := strings.CutPrefix(line, "mirrors:"); ok {
+			if len(entries) == 0 {
+				continue
+			}
 
-		if url == "" {
+			last := &entries[len(entries)-1]
+			for _, m := range strings.Split(rest, ",") {
+				if m = strings.TrimSpace(m); m != "" {
+					last.mirrors = append(last.mirrors, m)
+				}
+			}
 			continue
 		}
 
-		if err := download(ctx, c, g, url); err != nil {
+		entries = append(entries, batchEntry{url: line})
+	}
+
+	return entries
+}
+
+func multiDownload(ctx context.Context, c *cli.Context, g *got.Got, scanner *bufio.Scanner) error {
+	for _, e := range parseBatch(scanner) {
+		if err := download(ctx, c, g, e.url, append(c.StringSlice("mirror"), e.mirrors...)); err != nil {
 			return err
 		}
 
 		fmt.Print("\x1b[2K")
-		fmt.Printf("✔ %s\n", url)
+		fmt.Printf("✔ %s\n", e.url)
 	}
 
 	return nil
 }
 
-func download(ctx context.Context, c *cli.Context, g *got.Got, url string) (err error) {
+func download(ctx context.Context, c *cli.Context, g *got.Got, url string, mirrors []string) (err error) {
 	if url, err = getURL(url); err != nil {
 		return err
 	}
 
+	limitRate, err := parseRate(c.String("limit-rate"))
+	if err != nil {
+		return err
+	}
+
 	return g.Do(&got.Download{
-		URL:         url,
-		Dir:         c.String("dir"),
-		Dest:        c.String("output"),
-		Header:      HeaderSlice,
-		Interval:    150,
-		ChunkSize:   c.Uint64("size"),
-		Concurrency: c.Uint("concurrency"),
+		URL:             url,
+		Dir:             c.String("dir"),
+		Dest:            c.String("output"),
+		Header:          HeaderSlice,
+		Interval:        150,
+		ChunkSize:       c.Uint64("size"),
+		Concurrency:     c.Uint("concurrency"),
+		Checksum:        c.String("checksum"),
+		Resume:          c.Bool("continue"),
+		Extract:         c.Bool("extract"),
+		Format:          c.String("format"),
+		StripComponents: c.Int("strip-components"),
+		Mirrors:         mirrors,
+		MaxBytesPerSec:  limitRate,
+		RetryPolicy: &got.RetryPolicy{
+			MaxRetries: c.Int("max-retries"),
+			Wait:       c.Duration("retry-wait"),
+			Timeout:    c.Duration("timeout"),
+		},
 	})
 }
 
+// parseRate parses a bytefmt-style rate like "2M" or "512K" into
+// bytes/sec. An empty string means unlimited.
+func parseRate(s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.TrimSuffix(s, "B")
+
+	multiplier := uint64(1)
+	switch {
+	case strings.HasSuffix(s, "K"):
+		multiplier = 1 << 10
+		s = strings.TrimSuffix(s, "K")
+	case strings.HasSuffix(s, "M"):
+		multiplier = 1 << 20
+		s = strings.TrimSuffix(s, "M")
+	case strings.HasSuffix(s, "G"):
+		multiplier = 1 << 30
+		s = strings.TrimSuffix(s, "G")
+	case strings.HasSuffix(s, "T"):
+		multiplier = 1 << 40
+		s = strings.TrimSuffix(s, "T")
+	}
+
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("malformatted --limit-rate %q", s)
+	}
+
+	return n * multiplier, nil
+}
+
+// formatRate renders bytes/sec for the progress bar's description.
+func formatRate(bytesPerSec uint64) string {
+	const unit = 1024
+
+	if bytesPerSec < unit {
+		return fmt.Sprintf("%d B/s", bytesPerSec)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := bytesPerSec / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB/s", float64(bytesPerSec)/float64(div), "KMGT"[exp])
+}
+
+// formatMirrorStats renders per-mirror throughput for the progress bar's
+// description, e.g. " [https://a/f: 1.2 MiB/s, https://b/f: 512.0 KiB/s]".
+func formatMirrorStats(stats []got.MirrorStat) string {
+	if len(stats) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(stats))
+	for i, s := range stats {
+		parts[i] = fmt.Sprintf("%s: %s", s.URL, formatRate(uint64(s.BytesPerSec)))
+	}
+
+	return " [" + strings.Join(parts, ", ") + "]"
+}
+
 func getURL(URL string) (string, error) {
+	// git:: is a pseudo-scheme (e.g. "git::https://github.com/foo/bar"),
+	// not a real URL; leave it untouched for got.Got.Do to dispatch on.
+	if strings.HasPrefix(URL, "git::") {
+		return URL, nil
+	}
+
 	u, err := url.Parse(URL)
 	if err != nil {
 		return "", err
@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+func TestParseRate(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    uint64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"512", 512, false},
+		{"2K", 2 << 10, false},
+		{"2M", 2 << 20, false},
+		{"1G", 1 << 30, false},
+		{"1T", 1 << 40, false},
+		{"2MB", 2 << 20, false},
+		{"2m", 2 << 20, false},
+		{"nope", 0, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			got, err := parseRate(c.in)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("parseRate(%q) error = %v, wantErr %v", c.in, err, c.wantErr)
+			}
+			if err == nil && got != c.want {
+				t.Fatalf("parseRate(%q) = %d, want %d", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+func TestFormatRate(t *testing.T) {
+	cases := []struct {
+		in   uint64
+		want string
+	}{
+		{500, "500 B/s"},
+		{1024, "1.0 KiB/s"},
+		{1 << 20, "1.0 MiB/s"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.want, func(t *testing.T) {
+			if got := formatRate(c.in); got != c.want {
+				t.Fatalf("formatRate(%d) = %q, want %q", c.in, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package got
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Decompressor decompresses or extracts src into dst. Archive
+// implementations (tar, zip, ...) write a tree rooted at dst; plain
+// compressors (gzip, bzip2, ...) write a single file at dst.
+type Decompressor interface {
+	// Decompress reads src (the fully downloaded file) and writes the
+	// result to dst, honoring opts.
+	Decompress(dst string, src io.Reader, opts DecompressOptions) error
+}
+
+// DecompressOptions tweaks how an archive is extracted.
+type DecompressOptions struct {
+	// StripComponents removes the first N path elements of each archive
+	// entry, like tar's --strip-components.
+	StripComponents int
+}
+
+// decompressors maps a format name (as passed to --format, or detected
+// from a URL/Content-Type/magic bytes) to the Decompressor that handles
+// it. Archive formats that stack a compressor (tar.gz, tar.bz2, tar.xz)
+// are registered under both their long and short aliases.
+var decompressors = map[string]Decompressor{
+	"gz":   gzipDecompressor{},
+	"gzip": gzipDecompressor{},
+
+	"bz2":   bzip2Decompressor{},
+	"bzip2": bzip2Decompressor{},
+
+	"xz": xzDecompressor{},
+
+	"zst":  zstdDecompressor{},
+	"zstd": zstdDecompressor{},
+
+	"tar": tarDecompressor{},
+
+	"tar.gz": tarDecompressor{compression: "gz"},
+	"tgz":    tarDecompressor{compression: "gz"},
+
+	"tar.bz2": tarDecompressor{compression: "bz2"},
+	"tbz2":    tarDecompressor{compression: "bz2"},
+
+	"tar.xz": tarDecompressor{compression: "xz"},
+	"txz":    tarDecompressor{compression: "xz"},
+
+	"zip": zipDecompressor{},
+}
+
+// RegisterDecompressor registers, or overrides, the Decompressor used for
+// a given format name (e.g. "tar.gz").
+func RegisterDecompressor(format string, d Decompressor) {
+	decompressors[format] = d
+}
+
+// magicDetectors are checked, in order, against the first bytes of the
+// downloaded file when the format can't be determined from the URL or
+// Content-Type.
+var magicDetectors = []struct {
+	format string
+	magic  []byte
+}{
+	{"gz", []byte{0x1f, 0x8b}},
+	{"bz2", []byte("BZh")},
+	{"xz", []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}},
+	{"zst", []byte{0x28, 0xb5, 0x2f, 0xfd}},
+	{"zip", []byte("PK\x03\x04")},
+}
+
+// detectFormat figures out the archive/compression format of a download,
+// preferring (in order) an explicit Format, the destination's extension,
+// the response Content-Type, and finally the magic bytes of its first
+// chunk.
+func detectFormat(format, dest, contentType string, head []byte) string {
+	if format != "" {
+		return strings.ToLower(format)
+	}
+
+	if ext := formatFromExt(dest); ext != "" {
+		return ext
+	}
+
+	if ct := formatFromContentType(contentType); ct != "" {
+		return ct
+	}
+
+	for _, d := range magicDetectors {
+		if bytes.HasPrefix(head, d.magic) {
+			return d.format
+		}
+	}
+
+	return ""
+}
+
+func formatFromExt(name string) string {
+	lower := strings.ToLower(name)
+
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return "tar.gz"
+	case strings.HasSuffix(lower, ".tar.bz2"), strings.HasSuffix(lower, ".tbz2"):
+		return "tar.bz2"
+	case strings.HasSuffix(lower, ".tar.xz"), strings.HasSuffix(lower, ".txz"):
+		return "tar.xz"
+	case strings.HasSuffix(lower, ".tar"):
+		return "tar"
+	case strings.HasSuffix(lower, ".zip"):
+		return "zip"
+	case strings.HasSuffix(lower, ".gz"):
+		return "gz"
+	case strings.HasSuffix(lower, ".bz2"):
+		return "bz2"
+	case strings.HasSuffix(lower, ".xz"):
+		return "xz"
+	case strings.HasSuffix(lower, ".zst"):
+		return "zst"
+	default:
+		return ""
+	}
+}
+
+func formatFromContentType(contentType string) string {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	switch mt {
+	case "application/gzip", "application/x-gzip":
+		return "gz"
+	case "application/x-bzip2":
+		return "bz2"
+	case "application/x-xz":
+		return "xz"
+	case "application/zstd":
+		return "zst"
+	case "application/zip":
+		return "zip"
+	case "application/x-tar":
+		return "tar"
+	default:
+		return ""
+	}
+}
+
+// extract runs the resolved decompressor over the downloaded file.
+// Archives are extracted into Dir; plain compressors write a single
+// output file (the destination name with the compression suffix
+// stripped).
+func (d *Download) extract() error {
+	if !d.Extract {
+		return nil
+	}
+
+	f, err := os.Open(d.filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(f, head)
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	format := detectFormat(d.Format, d.filename, d.contentType, head[:n])
+	if format == "" {
+		return fmt.Errorf("got: couldn't detect archive/compression format for %q", d.filename)
+	}
+
+	dec, ok := decompressors[format]
+	if !ok {
+		return fmt.Errorf("got: no decompressor registered for format %q", format)
+	}
+
+	dst := d.Dir
+	if dst == "" {
+		dst = "."
+	}
+
+	if _, ok := dec.(plainDecompressor); ok {
+		dst = strings.TrimSuffix(d.filename, filepath.Ext(d.filename))
+
+		// d.filename has no extension to strip (format was detected from
+		// Content-Type or magic bytes); fall back to a distinct name so
+		// Decompress doesn't os.Create the same path it's still reading
+		// from src, truncating the source mid-read.
+		if dst == d.filename {
+			dst = d.filename + ".out"
+		}
+	}
+
+	return dec.Decompress(dst, f, DecompressOptions{StripComponents: d.StripComponents})
+}
+
+// plainDecompressor marks Decompressors that produce a single output
+// file rather than an extracted tree, so extract() knows how to build
+// their destination path.
+type plainDecompressor interface {
+	Decompressor
+	plain()
+}
@@ -0,0 +1,15 @@
+package got
+
+import (
+	"compress/bzip2"
+	"io"
+)
+
+// bzip2Decompressor decompresses a single bzip2-compressed file.
+type bzip2Decompressor struct{}
+
+func (bzip2Decompressor) plain() {}
+
+func (bzip2Decompressor) Decompress(dst string, src io.Reader, _ DecompressOptions) error {
+	return writeFile(dst, bzip2.NewReader(src))
+}
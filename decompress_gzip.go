@@ -0,0 +1,33 @@
+package got
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+)
+
+// gzipDecompressor decompresses a single gzip-compressed file.
+type gzipDecompressor struct{}
+
+func (gzipDecompressor) plain() {}
+
+func (gzipDecompressor) Decompress(dst string, src io.Reader, _ DecompressOptions) error {
+	r, err := gzip.NewReader(src)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	return writeFile(dst, r)
+}
+
+func writeFile(dst string, r io.Reader) error {
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
@@ -0,0 +1,136 @@
+package got
+
+import (
+	"archive/tar"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ulikunitz/xz"
+)
+
+// tarDecompressor extracts a tar archive, optionally wrapped in a
+// compressor (gzip, bzip2, xz).
+type tarDecompressor struct {
+	// compression is "", "gz", "bz2" or "xz".
+	compression string
+}
+
+func (t tarDecompressor) Decompress(dst string, src io.Reader, opts DecompressOptions) error {
+	r, err := t.wrap(src)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dst, os.ModePerm); err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(r)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name, ok := safeArchiveEntryPath(dst, hdr.Name, opts.StripComponents)
+		if !ok {
+			continue
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(name, os.ModePerm); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(name), os.ModePerm); err != nil {
+				return err
+			}
+			if err := writeFileMode(name, tr, os.FileMode(hdr.Mode)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (t tarDecompressor) wrap(src io.Reader) (io.Reader, error) {
+	switch t.compression {
+	case "gz":
+		return gzip.NewReader(src)
+	case "bz2":
+		return bzip2.NewReader(src), nil
+	case "xz":
+		return xz.NewReader(src)
+	default:
+		return src, nil
+	}
+}
+
+// safeArchiveEntryPath joins dst with an archive entry's name after
+// stripping the first stripComponents path elements, rejecting entries
+// that would escape dst (zip-slip) via ".." or an absolute path.
+func safeArchiveEntryPath(dst, name string, stripComponents int) (string, bool) {
+	clean := filepath.ToSlash(filepath.Clean(name))
+
+	if stripComponents > 0 {
+		parts := strings.Split(clean, "/")
+		if stripComponents >= len(parts) {
+			return "", false
+		}
+		clean = strings.Join(parts[stripComponents:], "/")
+	}
+
+	if clean == "" || clean == "." {
+		return "", false
+	}
+
+	if filepath.IsAbs(clean) || strings.HasPrefix(clean, "../") || clean == ".." {
+		return "", false
+	}
+
+	full := filepath.Join(dst, clean)
+
+	// Compare resolved absolute paths rather than dst's literal prefix:
+	// dst is "." by default (no -d given), and filepath.Join(".", "foo")
+	// == "foo", which never has a "./" prefix, so a literal-prefix check
+	// rejects every entry in the common no-dir case.
+	dstAbs, err := filepath.Abs(dst)
+	if err != nil {
+		return "", false
+	}
+	fullAbs, err := filepath.Abs(full)
+	if err != nil {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(dstAbs, fullAbs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		return "", false
+	}
+
+	return full, true
+}
+
+func writeFileMode(dst string, r io.Reader, mode os.FileMode) error {
+	if mode == 0 {
+		mode = 0o644
+	}
+
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return fmt.Errorf("got: %w", err)
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
@@ -0,0 +1,53 @@
+package got
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSafeArchiveEntryPath(t *testing.T) {
+	cases := []struct {
+		name            string
+		dst             string
+		entry           string
+		stripComponents int
+		wantOK          bool
+	}{
+		{"dst is current dir (default, no -d)", ".", "foo/bar.txt", 0, true},
+		{"nested file under a real dir", "out", "a/b/c.txt", 0, true},
+		{"parent traversal rejected", "out", "../etc/passwd", 0, false},
+		{"parent traversal rejected under dot dst", ".", "../etc/passwd", 0, false},
+		{"absolute path rejected", "out", "/etc/passwd", 0, false},
+		{"strip components", "out", "pkg/a/b.txt", 1, true},
+		{"inner traversal resolving above dst rejected", "out", "pkg/../../etc/passwd", 0, false},
+		{"strip beyond entry depth rejected", "out", "a.txt", 1, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, ok := safeArchiveEntryPath(c.dst, c.entry, c.stripComponents)
+			if ok != c.wantOK {
+				t.Fatalf("safeArchiveEntryPath(%q, %q, %d) ok = %v, want %v (path %q)",
+					c.dst, c.entry, c.stripComponents, ok, c.wantOK, got)
+			}
+			if !ok {
+				return
+			}
+
+			dstAbs, err := filepath.Abs(c.dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotAbs, err := filepath.Abs(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rel, err := filepath.Rel(dstAbs, gotAbs)
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				t.Fatalf("resolved path %q escapes dst %q", got, c.dst)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package got
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+func TestGzipDecompressor(t *testing.T) {
+	want := []byte("hello gzip world")
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	gw.Write(want)
+	gw.Close()
+
+	dst := filepath.Join(t.TempDir(), "out")
+	if err := (gzipDecompressor{}).Decompress(dst, &buf, DecompressOptions{}); err != nil {
+		t.Fatalf("Decompress: %v", err)
+	}
+
+	assertFileContent(t, dst, want)
+}
+
+// bzip2FixtureBase64 is "hello bzip2 world" compressed with bzip2; the
+// stdlib only ships a reader, so the fixture was generated once with an
+// external encoder rather than round-tripped through Go.
+const bzip2FixtureBase64 = "QlpoOTFBWSZTWR9OcLoAAAMZgEAAEAAWZNCQIAAxANABTANGlqGF0dyPE6Dwu5IpwoSA+nOF0A=="
+
+func TestBzip2Decompressor(t *testing.T) {
+	compressed, err := base64.StdEncoding.DecodeString(bzip2FixtureBase64)
+	if err != nil {
+		t.Fatalf("decoding fixture: %v", err)
+	}
+
+	dst := filepath.Join(t.TempDir(), "out")
+	if err := (bzip2Decompressor{}).Decompress(dst, bytes.NewReader(compressed), DecompressOptions{}); err != nil {
+		t.Fatalf("Decompress: %v", err)
+	}
+
+	assertFileContent(t, dst, []byte("hello bzip2 world"))
+}
+
+func TestXzDecompressor(t *testing.T) {
+	want := []byte("hello xz world")
+
+	var buf bytes.Buffer
+	xw, err := xz.NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("xz.NewWriter: %v", err)
+	}
+	xw.Write(want)
+	xw.Close()
+
+	dst := filepath.Join(t.TempDir(), "out")
+	if err := (xzDecompressor{}).Decompress(dst, &buf, DecompressOptions{}); err != nil {
+		t.Fatalf("Decompress: %v", err)
+	}
+
+	assertFileContent(t, dst, want)
+}
+
+func TestZstdDecompressor(t *testing.T) {
+	want := []byte("hello zstd world")
+
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatalf("zstd.NewWriter: %v", err)
+	}
+	compressed := enc.EncodeAll(want, nil)
+	enc.Close()
+
+	dst := filepath.Join(t.TempDir(), "out")
+	if err := (zstdDecompressor{}).Decompress(dst, bytes.NewReader(compressed), DecompressOptions{}); err != nil {
+		t.Fatalf("Decompress: %v", err)
+	}
+
+	assertFileContent(t, dst, want)
+}
+
+func TestZipDecompressorMultipleEntries(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	write := func(name, content string) {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zw.Create(%q): %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("write %q: %v", name, err)
+		}
+	}
+	write("a.txt", "file a")
+	write("dir/b.txt", "file b")
+
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zw.Close: %v", err)
+	}
+
+	dst := t.TempDir()
+	if err := (zipDecompressor{}).Decompress(dst, bytes.NewReader(buf.Bytes()), DecompressOptions{}); err != nil {
+		t.Fatalf("Decompress: %v", err)
+	}
+
+	assertFileContent(t, filepath.Join(dst, "a.txt"), []byte("file a"))
+	assertFileContent(t, filepath.Join(dst, "dir", "b.txt"), []byte("file b"))
+}
+
+func TestTarGzDecompressor(t *testing.T) {
+	var rawBuf bytes.Buffer
+	tw := tar.NewWriter(&rawBuf)
+
+	content := []byte("file inside tar.gz")
+	if err := tw.WriteHeader(&tar.Header{Name: "nested/file.txt", Mode: 0o644, Size: int64(len(content))}); err != nil {
+		t.Fatalf("WriteHeader: %v", err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tw.Close: %v", err)
+	}
+
+	var gzBuf bytes.Buffer
+	gw := gzip.NewWriter(&gzBuf)
+	gw.Write(rawBuf.Bytes())
+	gw.Close()
+
+	dst := t.TempDir()
+	dec := tarDecompressor{compression: "gz"}
+	if err := dec.Decompress(dst, &gzBuf, DecompressOptions{}); err != nil {
+		t.Fatalf("Decompress: %v", err)
+	}
+
+	assertFileContent(t, filepath.Join(dst, "nested", "file.txt"), content)
+}
+
+func TestTarStripComponents(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	content := []byte("stripped")
+	if err := tw.WriteHeader(&tar.Header{Name: "pkg/nested/file.txt", Mode: 0o644, Size: int64(len(content))}); err != nil {
+		t.Fatalf("WriteHeader: %v", err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tw.Close: %v", err)
+	}
+
+	dst := t.TempDir()
+	dec := tarDecompressor{}
+	if err := dec.Decompress(dst, &buf, DecompressOptions{StripComponents: 1}); err != nil {
+		t.Fatalf("Decompress: %v", err)
+	}
+
+	assertFileContent(t, filepath.Join(dst, "nested", "file.txt"), content)
+}
+
+func assertFileContent(t *testing.T, path string, want []byte) {
+	t.Helper()
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile(%q): %v", path, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s content = %q, want %q", path, got, want)
+	}
+}
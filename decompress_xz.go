@@ -0,0 +1,21 @@
+package got
+
+import (
+	"io"
+
+	"github.com/ulikunitz/xz"
+)
+
+// xzDecompressor decompresses a single xz-compressed file.
+type xzDecompressor struct{}
+
+func (xzDecompressor) plain() {}
+
+func (xzDecompressor) Decompress(dst string, src io.Reader, _ DecompressOptions) error {
+	r, err := xz.NewReader(src)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(dst, r)
+}
@@ -0,0 +1,79 @@
+package got
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// zipDecompressor extracts a zip archive.
+type zipDecompressor struct{}
+
+func (zipDecompressor) Decompress(dst string, src io.Reader, opts DecompressOptions) error {
+	ra, size, err := readerAt(src)
+	if err != nil {
+		return err
+	}
+	defer ra.Close()
+
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dst, os.ModePerm); err != nil {
+		return err
+	}
+
+	for _, f := range zr.File {
+		name, ok := safeArchiveEntryPath(dst, f.Name, opts.StripComponents)
+		if !ok {
+			continue
+		}
+
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(name, os.ModePerm); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(name), os.ModePerm); err != nil {
+			return err
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+
+		err = writeFileMode(name, rc, f.Mode())
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readerAt buffers src into a temp file so archive/zip, which needs
+// io.ReaderAt and the total size, can read it without loading the whole
+// archive into memory. The file is unlinked immediately but the caller
+// must Close the returned *os.File once done, or its descriptor leaks.
+func readerAt(src io.Reader) (*os.File, int64, error) {
+	tmp, err := os.CreateTemp("", "got-zip-*")
+	if err != nil {
+		return nil, 0, err
+	}
+	os.Remove(tmp.Name())
+
+	size, err := io.Copy(tmp, src)
+	if err != nil {
+		tmp.Close()
+		return nil, 0, err
+	}
+
+	return tmp, size, nil
+}
@@ -0,0 +1,22 @@
+package got
+
+import (
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstdDecompressor decompresses a single zstd-compressed file.
+type zstdDecompressor struct{}
+
+func (zstdDecompressor) plain() {}
+
+func (zstdDecompressor) Decompress(dst string, src io.Reader, _ DecompressOptions) error {
+	r, err := zstd.NewReader(src)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	return writeFile(dst, r)
+}
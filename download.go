@@ -0,0 +1,466 @@
+package got
+
+import (
+	"context"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Download describes a single file to fetch and how to fetch it.
+type Download struct {
+	// URL is the address to download from.
+	URL string
+
+	// Dir is the destination directory, defaults to the current
+	// working directory.
+	Dir string
+
+	// Dest overrides the destination file name, defaults to the
+	// basename of URL.
+	Dest string
+
+	// Header are extra HTTP headers sent with every request.
+	Header []GotHeader
+
+	// Interval is the progress report interval in milliseconds.
+	Interval int
+
+	// ChunkSize is the size in bytes of each chunk, 0 lets Got pick one.
+	ChunkSize uint64
+
+	// Concurrency is the number of chunks downloaded in parallel,
+	// 0 lets Got pick one.
+	Concurrency uint
+
+	// Checksum, in the form "algo:hex" (e.g. "sha256:abcd..."), or a
+	// URL pointing to a checksums file, verifies the downloaded file's
+	// integrity once it's fully written.
+	Checksum string
+
+	// Resume restarts an interrupted download from its sidecar ".got"
+	// state file instead of re-fetching chunks already written.
+	Resume bool
+
+	// Extract decompresses/extracts the download once it's complete.
+	Extract bool
+
+	// Format forces the archive/compression format used by Extract,
+	// e.g. "tar.gz"; left empty, it's auto-detected.
+	Format string
+
+	// StripComponents removes the first N path elements of each archive
+	// entry when Extract is set, like tar's --strip-components.
+	StripComponents int
+
+	// Mirrors are extra source URLs for the same resource. The HTTP
+	// getter HEADs them all, drops the primary URL in, picks the
+	// fastest responders and spreads chunk requests across them,
+	// retrying a failed chunk against another mirror before giving up.
+	Mirrors []string
+
+	// MaxBytesPerSec caps the download's aggregate bandwidth across all
+	// concurrent chunk readers, 0 means unlimited.
+	MaxBytesPerSec uint64
+
+	// RetryPolicy controls retries of transient chunk failures; nil
+	// falls back to DefaultRetryPolicy.
+	RetryPolicy *RetryPolicy
+
+	// Context, if set, overrides the Got's context for this download.
+	Context context.Context
+
+	got    *Got
+	client *http.Client
+
+	size     uint64
+	written  uint64
+	filename string
+
+	chunkable    bool
+	etag         string
+	lastModified string
+	contentType  string
+
+	hash             hash.Hash
+	checksumAlgo     string
+	checksumExpected string
+
+	// fetchRange is supplied by the resolved Getter and opens a reader
+	// for the [offset, offset+length) byte range of the source. The
+	// chunk engine below is Getter-agnostic; it doesn't know or care
+	// whether that range comes from an HTTP Range request, an S3 ranged
+	// GetObject, or a local file's SectionReader.
+	fetchRange func(ctx context.Context, offset, length int64) (io.ReadCloser, error)
+
+	bucket *tokenBucket
+	rate   uint64 // bytes/sec, updated by reportProgress
+
+	mu sync.Mutex
+}
+
+// Rate returns the download's current throttled throughput in
+// bytes/sec, as last measured at Interval.
+func (d *Download) Rate() uint64 {
+	return atomic.LoadUint64(&d.rate)
+}
+
+// Size returns the number of bytes downloaded so far. Bytes are counted
+// per chunk, once the whole chunk has been written; a chunk still in
+// flight adds nothing until it completes.
+func (d *Download) Size() uint64 {
+	return atomic.LoadUint64(&d.written)
+}
+
+// TotalSize returns the total size of the remote file, 0 if unknown.
+func (d *Download) TotalSize() uint64 {
+	return atomic.LoadUint64(&d.size)
+}
+
+// setSize records the total size of the source, as resolved by a Getter.
+func (d *Download) setSize(size uint64) {
+	atomic.StoreUint64(&d.size, size)
+}
+
+// Filename returns the resolved destination path.
+func (d *Download) Filename() string {
+	return d.filename
+}
+
+func (d *Download) ctx() context.Context {
+	if d.Context != nil {
+		return d.Context
+	}
+	if d.got != nil && d.got.Context != nil {
+		return d.got.Context
+	}
+	return context.Background()
+}
+
+func (d *Download) httpClient() *http.Client {
+	if d.client != nil {
+		return d.client
+	}
+	return http.DefaultClient
+}
+
+func (d *Download) resolveFilename() error {
+	if d.Dest == "" {
+		u, err := urlPath(d.URL)
+		if err != nil {
+			return err
+		}
+		d.Dest = filepath.Base(u)
+	}
+
+	dir := d.Dir
+	if dir == "" {
+		dir = "."
+	}
+
+	d.filename = filepath.Join(dir, d.Dest)
+	return nil
+}
+
+func (d *Download) newRequest(method string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(d.ctx(), method, d.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", UserAgent)
+
+	for _, h := range d.Header {
+		req.Header.Set(h.Key, h.Value)
+	}
+
+	return req, nil
+}
+
+func (d *Download) download() error {
+	chunks, resumed, err := d.resolveChunks()
+	if err != nil {
+		return err
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY
+	if !resumed {
+		flags |= os.O_TRUNC
+	}
+
+	f, err := os.OpenFile(d.filename, flags, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for _, c := range chunks {
+		if c.done {
+			atomic.AddUint64(&d.written, uint64(c.length))
+		}
+	}
+
+	if d.MaxBytesPerSec > 0 {
+		d.bucket = newTokenBucket(d.MaxBytesPerSec)
+	}
+
+	concurrency := d.Concurrency
+	if concurrency == 0 {
+		concurrency = 1
+	}
+
+	stop := d.reportProgress()
+	defer stop()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	sem := make(chan struct{}, concurrency)
+
+	for _, c := range chunks {
+		if c.done {
+			continue
+		}
+
+		c := c
+		sem <- struct{}{}
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := d.downloadChunk(f, c)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+
+			c.done = true
+			if d.Resume {
+				d.saveState(chunks)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	removeState(d.filename)
+	return nil
+}
+
+// resolveChunks builds the chunk plan for the download, either fresh or,
+// when Resume is set and a matching sidecar state file is found, picking
+// up where a previous, interrupted run left off.
+func (d *Download) resolveChunks() (chunks []*chunk, resumed bool, err error) {
+	fresh := func() []*chunk {
+		concurrency := d.Concurrency
+		if concurrency == 0 {
+			concurrency = 1
+		}
+
+		if !d.chunkable || concurrency <= 1 || d.size == 0 {
+			return []*chunk{{offset: 0, length: int64(d.size)}}
+		}
+
+		return splitChunks(d.size, d.ChunkSize, concurrency)
+	}
+
+	if !d.Resume {
+		removeState(d.filename)
+		return fresh(), false, nil
+	}
+
+	st, err := loadState(d.filename)
+	if os.IsNotExist(err) {
+		return fresh(), false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("got: can't continue download: %w", err)
+	}
+
+	if !d.chunkable || st.URL != d.URL || st.Size != d.size || st.ETag != d.etag || st.LastModified != d.lastModified {
+		return nil, false, fmt.Errorf("got: can't continue download: %w", ErrResumeUnsupported)
+	}
+
+	chunks = make([]*chunk, len(st.Chunks))
+	for i, c := range st.Chunks {
+		chunks[i] = &chunk{offset: c.Offset, length: c.Length, done: c.Done}
+	}
+
+	return chunks, true, nil
+}
+
+func (d *Download) saveState(chunks []*chunk) error {
+	st := &downloadState{
+		URL:          d.URL,
+		ETag:         d.etag,
+		LastModified: d.lastModified,
+		Size:         d.size,
+	}
+
+	for _, c := range chunks {
+		st.Chunks = append(st.Chunks, chunkState{Offset: c.offset, Length: c.length, Done: c.done})
+	}
+
+	return st.save(d.filename)
+}
+
+// chunk describes a byte range of the remote file and whether it has
+// already been downloaded (used when resuming).
+type chunk struct {
+	offset int64
+	length int64
+	done   bool
+}
+
+func splitChunks(size uint64, chunkSize uint64, concurrency uint) []*chunk {
+	if chunkSize == 0 {
+		chunkSize = size / uint64(concurrency)
+		if chunkSize == 0 {
+			chunkSize = size
+		}
+	}
+
+	var chunks []*chunk
+	for offset := uint64(0); offset < size; offset += chunkSize {
+		length := chunkSize
+		if offset+length > size {
+			length = size - offset
+		}
+		chunks = append(chunks, &chunk{offset: int64(offset), length: int64(length)})
+	}
+
+	return chunks
+}
+
+// downloadChunk fetches and writes c, retrying the whole attempt (not just
+// the initial request) against d's RetryPolicy: a connection reset or
+// truncated body mid-transfer is exactly as transient as a failed dial, so
+// it must be retried too, not just surfaced as a dead download.
+func (d *Download) downloadChunk(f *os.File, c *chunk) error {
+	policy := d.retryPolicy()
+
+	var lastErr error
+
+	for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
+		written, err := d.fetchChunk(f, c, policy)
+		if err == nil {
+			atomic.AddUint64(&d.written, uint64(written))
+			return nil
+		}
+
+		// Only the bytes of a successful attempt count; a failed
+		// attempt's partial write is overwritten by the retry, which
+		// re-fetches and re-copies the chunk from its start offset.
+		if d.hash != nil {
+			d.hash.Reset()
+		}
+
+		lastErr = err
+
+		if attempt == policy.MaxRetries || !isTransient(err) {
+			return lastErr
+		}
+
+		if werr := sleepBackoff(d.ctx(), policy, attempt, err); werr != nil {
+			return werr
+		}
+	}
+
+	return lastErr
+}
+
+// fetchChunk runs a single attempt: open the range, copy it to f at c's
+// offset (hashing as it goes, if a Checksum was requested), all bounded by
+// policy.Timeout.
+func (d *Download) fetchChunk(f *os.File, c *chunk, policy RetryPolicy) (int64, error) {
+	ctx := d.ctx()
+
+	if policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		defer cancel()
+	}
+
+	rc, err := d.fetchRange(ctx, c.offset, c.length)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	w := io.NewOffsetWriter(f, c.offset)
+
+	return d.copyAndHash(w, throttle(ctx, rc, d.bucket))
+}
+
+func (d *Download) reportProgress() (stop func()) {
+	if d.got == nil || d.got.ProgressFunc == nil {
+		return func() {}
+	}
+
+	interval := time.Duration(d.Interval) * time.Millisecond
+	if interval <= 0 {
+		interval = 150 * time.Millisecond
+	}
+
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	lastWritten := d.Size()
+	lastTick := time.Now()
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				now := time.Now()
+				written := d.Size()
+
+				if elapsed := now.Sub(lastTick).Seconds(); elapsed > 0 {
+					atomic.StoreUint64(&d.rate, uint64(float64(written-lastWritten)/elapsed))
+				}
+
+				lastWritten, lastTick = written, now
+
+				d.got.ProgressFunc(d)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		ticker.Stop()
+		close(done)
+		d.got.ProgressFunc(d)
+	}
+}
+
+func urlPath(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	return u.Path, nil
+}
@@ -0,0 +1,138 @@
+package got
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDownloadRoundTripConcurrentChunks(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000) // 10000 bytes
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "file.bin", time.Time{}, bytes.NewReader(content))
+	}))
+	defer srv.Close()
+
+	dl := &Download{
+		URL:         srv.URL + "/file.bin",
+		Dir:         t.TempDir(),
+		Concurrency: 4,
+		ChunkSize:   2000,
+	}
+
+	if err := New().Do(dl); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	got, err := os.ReadFile(dl.Filename())
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("downloaded %d bytes, want %d bytes matching the source", len(got), len(content))
+	}
+}
+
+func TestDownloadVerifiesChecksum(t *testing.T) {
+	content := []byte("the quick brown fox jumps over the lazy dog")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "file.bin", time.Time{}, bytes.NewReader(content))
+	}))
+	defer srv.Close()
+
+	sum := sha256.Sum256(content)
+
+	dl := &Download{
+		URL:      srv.URL + "/file.bin",
+		Dir:      t.TempDir(),
+		Checksum: "sha256:" + hex.EncodeToString(sum[:]),
+	}
+
+	if err := New().Do(dl); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	got, err := os.ReadFile(dl.Filename())
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("downloaded content doesn't match the source")
+	}
+}
+
+func TestDownloadChecksumMismatchDeletesPartialFile(t *testing.T) {
+	content := []byte("the quick brown fox jumps over the lazy dog")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "file.bin", time.Time{}, bytes.NewReader(content))
+	}))
+	defer srv.Close()
+
+	dl := &Download{
+		URL:      srv.URL + "/file.bin",
+		Dir:      t.TempDir(),
+		Checksum: "sha256:" + hex.EncodeToString(make([]byte, 32)), // deliberately wrong
+	}
+
+	err := New().Do(dl)
+
+	var mismatch *ErrChecksumMismatch
+	if err == nil {
+		t.Fatal("expected a checksum mismatch error")
+	}
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Do error = %v, want *ErrChecksumMismatch", err)
+	}
+
+	if _, statErr := os.Stat(dl.Filename()); !os.IsNotExist(statErr) {
+		t.Fatalf("partial file still exists after a checksum mismatch: %v", statErr)
+	}
+}
+
+func TestDownloadConcurrencyWithChecksumIgnoresRangeSupport(t *testing.T) {
+	content := bytes.Repeat([]byte("x"), 5000)
+
+	// No Accept-Ranges header, and Range requests are ignored: the server
+	// always returns the whole body. A Checksum downgrades to a single
+	// sequential request, so this must still succeed.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}))
+	defer srv.Close()
+
+	sum := sha256.Sum256(content)
+
+	dl := &Download{
+		URL:         srv.URL + "/file.bin",
+		Dir:         t.TempDir(),
+		Concurrency: 2,
+		Checksum:    "sha256:" + hex.EncodeToString(sum[:]),
+	}
+
+	if err := New().Do(dl); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+}
+
+func TestResolveFilename(t *testing.T) {
+	d := &Download{URL: "https://example.com/path/to/file.tar.gz", Dir: "out"}
+
+	if err := d.resolveFilename(); err != nil {
+		t.Fatalf("resolveFilename: %v", err)
+	}
+
+	want := filepath.Join("out", "file.tar.gz")
+	if d.filename != want {
+		t.Fatalf("resolveFilename = %q, want %q", d.filename, want)
+	}
+}
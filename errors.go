@@ -0,0 +1,33 @@
+package got
+
+import "errors"
+
+var (
+	// ErrDownloadAborted is returned/logged when a download is canceled,
+	// e.g. by the SIGINT handler in cmd/got.
+	ErrDownloadAborted = errors.New("got: download aborted")
+
+	// ErrZeroSize is returned when Concurrency > 1 was requested but the
+	// server didn't report a Content-Length, so the download can't be
+	// split into chunks.
+	ErrZeroSize = errors.New("got: can't get the download size")
+
+	// ErrNoRangeSupport is returned when Concurrency > 1 was requested
+	// but the server doesn't advertise Accept-Ranges: bytes.
+	ErrNoRangeSupport = errors.New("got: server doesn't support ranges")
+
+	// ErrResumeUnsupported is wrapped and returned when --continue/Resume
+	// was requested but the download can't be resumed: the server no
+	// longer supports ranges, or the resource changed (ETag/Last-Modified/
+	// size) since the sidecar state was written.
+	ErrResumeUnsupported = errors.New("got: download can't be resumed, resource changed or ranges unsupported")
+
+	// ErrNoAliveMirrors is returned when every URL in Mirrors (plus the
+	// primary URL) failed its HEAD probe.
+	ErrNoAliveMirrors = errors.New("got: no mirror responded")
+
+	// ErrChecksumUnsupported is returned when Checksum was requested but
+	// the resolved Getter doesn't stream the download through a hash
+	// (e.g. git::, which clones a tree rather than fetching one file).
+	ErrChecksumUnsupported = errors.New("got: checksum verification isn't supported for this URL scheme")
+)
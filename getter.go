@@ -0,0 +1,62 @@
+package got
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Getter fetches a Download using whatever protocol it implements,
+// writing the result to dl.Filename() (or, for getters that produce a
+// tree rather than a single file, into dl.Dir).
+type Getter interface {
+	// Scheme is the URL scheme this Getter handles, e.g. "http" or "s3".
+	Scheme() string
+
+	// Fetch downloads dl. It's responsible for everything up to (but
+	// not including) checksum verification and extraction, which Got.Do
+	// handles uniformly across getters.
+	Fetch(ctx context.Context, dl *Download) error
+}
+
+var getters = map[string]Getter{}
+
+// RegisterGetter registers, or overrides, the Getter used for a URL
+// scheme.
+func RegisterGetter(g Getter) {
+	getters[g.Scheme()] = g
+}
+
+func init() {
+	// httpGetter handles both schemes; RegisterGetter only keys by one
+	// Scheme(), so register "https" directly.
+	RegisterGetter(httpGetter{})
+	getters["https"] = httpGetter{}
+
+	RegisterGetter(fileGetter{})
+	RegisterGetter(s3Getter{})
+	RegisterGetter(gsGetter{})
+	RegisterGetter(gitGetter{})
+}
+
+// getterFor resolves the Getter registered for rawURL's scheme. The
+// "git::" pseudo-scheme (e.g. "git::https://github.com/foo/bar") is
+// special-cased since it isn't a real URL scheme.
+func getterFor(rawURL string) (Getter, error) {
+	if strings.HasPrefix(rawURL, "git::") {
+		return getters["git"], nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	g, ok := getters[u.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("got: no getter registered for scheme %q", u.Scheme)
+	}
+
+	return g, nil
+}
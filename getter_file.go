@@ -0,0 +1,48 @@
+package got
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+)
+
+// fileGetter copies a local file:// URL, using the same chunked/parallel
+// engine as httpGetter so huge LAN-mounted files benefit too.
+type fileGetter struct{}
+
+func (fileGetter) Scheme() string { return "file" }
+
+func (fileGetter) Fetch(ctx context.Context, d *Download) error {
+	u, err := url.Parse(d.URL)
+	if err != nil {
+		return err
+	}
+
+	src, err := os.Open(u.Path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	d.setSize(uint64(info.Size()))
+	d.chunkable = true
+	d.lastModified = info.ModTime().String()
+
+	if d.Checksum != "" {
+		if err := d.initChecksum(); err != nil {
+			return err
+		}
+	}
+
+	d.fetchRange = func(_ context.Context, offset, length int64) (io.ReadCloser, error) {
+		return io.NopCloser(io.NewSectionReader(src, offset, length)), nil
+	}
+
+	return d.download()
+}
@@ -0,0 +1,40 @@
+package got
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// gitGetter clones a "git::<url>" pseudo-scheme repository (e.g.
+// "git::https://github.com/foo/bar") into Dir, shallowly, via the git
+// binary. Unlike the other getters it produces a tree, not a single
+// file, so Got.Do skips filename resolution for it.
+type gitGetter struct{}
+
+func (gitGetter) Scheme() string { return "git" }
+
+func (gitGetter) Fetch(ctx context.Context, d *Download) error {
+	repoURL := strings.TrimPrefix(d.URL, "git::")
+
+	dir := d.Dir
+	if dir == "" {
+		dir = "."
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "clone", "--depth=1", repoURL, dir)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("got: git clone %s: %w", repoURL, err)
+	}
+
+	return nil
+}
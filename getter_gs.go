@@ -0,0 +1,55 @@
+package got
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"cloud.google.com/go/storage"
+)
+
+// gsGetter fetches gs://bucket/object URLs using Application Default
+// Credentials, mapping ranged reads onto the existing chunk engine.
+type gsGetter struct{}
+
+func (gsGetter) Scheme() string { return "gs" }
+
+func (gsGetter) Fetch(ctx context.Context, d *Download) error {
+	u, err := url.Parse(d.URL)
+	if err != nil {
+		return err
+	}
+
+	bucket := u.Host
+	object := strings.TrimPrefix(u.Path, "/")
+
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("got: creating GCS client: %w", err)
+	}
+
+	obj := client.Bucket(bucket).Object(object)
+
+	attrs, err := obj.Attrs(ctx)
+	if err != nil {
+		return fmt.Errorf("got: gs attrs %s/%s: %w", bucket, object, err)
+	}
+
+	d.setSize(uint64(attrs.Size))
+	d.chunkable = true
+	d.etag = attrs.Etag
+
+	if d.Checksum != "" {
+		if err := d.initChecksum(); err != nil {
+			return err
+		}
+	}
+
+	d.fetchRange = func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+		return obj.NewRangeReader(ctx, offset, length)
+	}
+
+	return d.download()
+}
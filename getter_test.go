@@ -0,0 +1,35 @@
+package got
+
+import "testing"
+
+func TestGetterForDispatchesByScheme(t *testing.T) {
+	cases := []struct {
+		url    string
+		scheme string
+	}{
+		{"https://example.com/f", "http"}, // httpGetter handles both; Scheme() only reports "http"
+		{"http://example.com/f", "http"},
+		{"file:///tmp/f", "file"},
+		{"s3://bucket/key", "s3"},
+		{"gs://bucket/key", "gs"},
+		{"git::https://github.com/foo/bar", "git"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.url, func(t *testing.T) {
+			g, err := getterFor(c.url)
+			if err != nil {
+				t.Fatalf("getterFor(%q): %v", c.url, err)
+			}
+			if g.Scheme() != c.scheme {
+				t.Fatalf("getterFor(%q).Scheme() = %q, want %q", c.url, g.Scheme(), c.scheme)
+			}
+		})
+	}
+}
+
+func TestGetterForUnknownScheme(t *testing.T) {
+	if _, err := getterFor("ftp://example.com/f"); err == nil {
+		t.Fatal("expected an error for a scheme with no registered Getter")
+	}
+}
@@ -0,0 +1,97 @@
+// Package got is a fast, simple downloader with concurrent chunking,
+// progress reporting and configurable HTTP client/context.
+package got
+
+import (
+	"context"
+	"net/http"
+)
+
+// UserAgent is the default User-Agent header sent with every request,
+// override it to customize what the server sees.
+var UserAgent = "Got/2.0"
+
+// GotHeader represents a single HTTP header sent with download requests.
+type GotHeader struct {
+	Key   string
+	Value string
+}
+
+// Got is the download engine, it holds the http client and context shared
+// across downloads started through it.
+type Got struct {
+	// Client is the http client used for all requests, override it to
+	// customize transport, proxy or TLS settings.
+	Client *http.Client
+
+	// Context used to cancel in-flight downloads.
+	Context context.Context
+
+	// ProgressFunc, when set, is called on Interval for every Download
+	// started through this Got.
+	ProgressFunc func(*Download)
+
+	// MirrorStatsFunc, when set, is called for downloads with Mirrors
+	// each time a chunk fetched from a mirror is closed, reporting
+	// per-mirror throughput. It runs on the chunk's goroutine, not on
+	// ProgressFunc's Interval.
+	MirrorStatsFunc func(*Download, []MirrorStat)
+}
+
+// New creates a new *Got with a background context.
+func New() *Got {
+	return NewWithContext(context.Background())
+}
+
+// NewWithContext creates a new *Got using ctx to cancel downloads.
+func NewWithContext(ctx context.Context) *Got {
+	return &Got{
+		Client:  http.DefaultClient,
+		Context: ctx,
+	}
+}
+
+// Do downloads the given Download and blocks until it's done, failed or
+// the Got's context is canceled. It dispatches to the Getter registered
+// for the URL's scheme (see RegisterGetter); a URL whose scheme has no
+// registered Getter, including one with no scheme at all, is an error.
+func (g *Got) Do(dl *Download) error {
+	dl.got = g
+
+	if dl.Context == nil {
+		dl.Context = g.Context
+	}
+
+	if dl.client == nil {
+		dl.client = g.Client
+	}
+
+	getter, err := getterFor(dl.URL)
+	if err != nil {
+		return err
+	}
+
+	// git:: clones a repository tree into Dir; it has no single
+	// destination file to resolve.
+	if _, ok := getter.(gitGetter); !ok {
+		if err := dl.resolveFilename(); err != nil {
+			return err
+		}
+	}
+
+	if err := getter.Fetch(dl.ctx(), dl); err != nil {
+		return err
+	}
+
+	// Getters that don't stream through a hash (e.g. gitGetter) leave
+	// dl.hash nil; a requested Checksum must not silently go unverified.
+	if dl.Checksum != "" && dl.hash == nil {
+		return ErrChecksumUnsupported
+	}
+
+	if err := dl.verifyChecksum(); err != nil {
+		return err
+	}
+
+	return dl.extract()
+}
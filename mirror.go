@@ -0,0 +1,245 @@
+package got
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MirrorStat reports a single mirror's measured performance, refreshed
+// as its chunks complete.
+type MirrorStat struct {
+	URL         string
+	TTFB        time.Duration
+	BytesPerSec float64
+	Bytes       uint64
+	Failures    int
+}
+
+// aliveMirror is a mirror that answered HEAD with a Content-Length (and
+// ETag, if the fastest responder returned one) matching the fastest
+// responder's.
+type aliveMirror struct {
+	url  string
+	ttfb time.Duration
+}
+
+// probeMirrors HEADs url and every extra mirror in parallel and returns
+// the ones whose Content-Length (and ETag, when present) agree with the
+// fastest responder, ordered fastest-first.
+func (d *Download) probeMirrors(ctx context.Context, urls []string) ([]aliveMirror, error) {
+	type result struct {
+		aliveMirror
+		contentLength int64
+		etag          string
+		err           error
+	}
+
+	results := make([]result, len(urls))
+
+	var wg sync.WaitGroup
+	for i, u := range urls {
+		i, u := i, u
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodHead, u, nil)
+			if err != nil {
+				results[i] = result{err: err}
+				return
+			}
+			req.Header.Set("User-Agent", UserAgent)
+
+			res, err := d.httpClient().Do(req)
+			if err != nil {
+				results[i] = result{err: err}
+				return
+			}
+			res.Body.Close()
+
+			results[i] = result{
+				aliveMirror:   aliveMirror{url: u, ttfb: time.Since(start)},
+				contentLength: res.ContentLength,
+				etag:          res.Header.Get("ETag"),
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Reference is whichever responder was fastest; the rest must agree
+	// with it to be considered the same resource.
+	var fastest *result
+	for i := range results {
+		if results[i].err != nil {
+			continue
+		}
+		if fastest == nil || results[i].ttfb < fastest.ttfb {
+			fastest = &results[i]
+		}
+	}
+
+	if fastest == nil {
+		return nil, ErrNoAliveMirrors
+	}
+
+	var alive []aliveMirror
+	for _, r := range results {
+		if r.err != nil {
+			continue
+		}
+		if r.contentLength != fastest.contentLength {
+			continue
+		}
+		if fastest.etag != "" && r.etag != fastest.etag {
+			continue
+		}
+		alive = append(alive, r.aliveMirror)
+	}
+
+	sortMirrorsByTTFB(alive)
+
+	return alive, nil
+}
+
+func sortMirrorsByTTFB(m []aliveMirror) {
+	for i := 1; i < len(m); i++ {
+		for j := i; j > 0 && m[j].ttfb < m[j-1].ttfb; j-- {
+			m[j], m[j-1] = m[j-1], m[j]
+		}
+	}
+}
+
+// mirrorFetcher builds a fetchRange func that spreads chunk requests
+// round-robin across alive mirrors, retrying a failed chunk against the
+// next mirror per d.retryPolicy() (so --max-retries/--retry-wait bound
+// and pace mirror fallback the same way they do the single-source
+// retries in downloadChunk), and reports MirrorStats.
+func (d *Download) mirrorFetcher(alive []aliveMirror) func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	stats := make([]MirrorStat, len(alive))
+	for i, m := range alive {
+		stats[i] = MirrorStat{URL: m.url, TTFB: m.ttfb}
+	}
+
+	var (
+		next uint64
+		mu   sync.Mutex
+	)
+
+	report := func() {
+		if d.got == nil || d.got.MirrorStatsFunc == nil {
+			return
+		}
+		mu.Lock()
+		snapshot := make([]MirrorStat, len(stats))
+		copy(snapshot, stats)
+		mu.Unlock()
+		d.got.MirrorStatsFunc(d, snapshot)
+	}
+
+	return func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+		policy := d.retryPolicy()
+
+		var lastErr error
+
+		for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
+			idx := int(atomic.AddUint64(&next, 1)-1) % len(alive)
+			m := alive[idx]
+
+			if attempt > 0 {
+				if werr := sleepBackoff(ctx, policy, attempt-1, lastErr); werr != nil {
+					return nil, werr
+				}
+			}
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.url, nil)
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("User-Agent", UserAgent)
+			req.Header.Set("Range", rangeHeader(offset, length))
+
+			res, err := d.httpClient().Do(req)
+			if err == nil && !validMirrorResponse(res, offset, length) {
+				res.Body.Close()
+				err = fmt.Errorf("status %s", res.Status)
+			}
+			if err != nil {
+				lastErr = fmt.Errorf("got: mirror %s failed: %w", m.url, err)
+				mu.Lock()
+				stats[idx].Failures++
+				mu.Unlock()
+				continue
+			}
+
+			// Bytes/sec has to be measured over the body read, not the
+			// time to get here: Do() only returns once headers arrive,
+			// so timing stops there would report time-to-first-byte
+			// instead of throughput. The counting wrapper below reports
+			// once the caller finishes reading (and Closes) the chunk.
+			return &mirrorBody{
+				ReadCloser: res.Body,
+				start:      time.Now(),
+				onClose: func(n int64, elapsed time.Duration) {
+					mu.Lock()
+					stats[idx].Bytes += uint64(n)
+					if elapsed.Seconds() > 0 {
+						stats[idx].BytesPerSec = float64(n) / elapsed.Seconds()
+					}
+					mu.Unlock()
+					report()
+				},
+			}, nil
+		}
+
+		return nil, lastErr
+	}
+}
+
+// mirrorBody wraps a mirror response body to measure real transfer
+// throughput: onClose fires once the caller has read (and closed) the
+// whole chunk, with the actual bytes read and time elapsed since start.
+type mirrorBody struct {
+	io.ReadCloser
+	start   time.Time
+	n       int64
+	onClose func(n int64, elapsed time.Duration)
+}
+
+func (m *mirrorBody) Read(p []byte) (int, error) {
+	n, err := m.ReadCloser.Read(p)
+	m.n += int64(n)
+	return n, err
+}
+
+func (m *mirrorBody) Close() error {
+	m.onClose(m.n, time.Since(m.start))
+	return m.ReadCloser.Close()
+}
+
+// validMirrorResponse reports whether res actually satisfies the ranged
+// request we sent: a 206 Partial Content, or a 200 only when the "range"
+// was the whole file (no real range support, single chunk). Anything
+// else — a 403/404/416, or an unranged 200 for a partial chunk — would
+// otherwise get its body spliced into the output file at offset.
+func validMirrorResponse(res *http.Response, offset, length int64) bool {
+	switch res.StatusCode {
+	case http.StatusPartialContent:
+		return true
+	case http.StatusOK:
+		return offset == 0 && res.ContentLength == length
+	default:
+		return false
+	}
+}
+
+func rangeHeader(offset, length int64) string {
+	return fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+}
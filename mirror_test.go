@@ -0,0 +1,175 @@
+package got
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// newMirrorServer starts a test server that answers HEAD with
+// Content-Length and GET with a 206 Partial Content body, optionally
+// delayed to simulate a slower mirror.
+func newMirrorServer(t *testing.T, body string, delay time.Duration) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		w.WriteHeader(http.StatusPartialContent)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestProbeMirrorsOrdersFastestFirst(t *testing.T) {
+	slow := newMirrorServer(t, "hello", 50*time.Millisecond)
+	fast := newMirrorServer(t, "hello", 0)
+
+	d := &Download{}
+
+	alive, err := d.probeMirrors(d.ctx(), []string{slow.URL, fast.URL})
+	if err != nil {
+		t.Fatalf("probeMirrors: %v", err)
+	}
+
+	if len(alive) != 2 {
+		t.Fatalf("probeMirrors returned %d alive mirrors, want 2", len(alive))
+	}
+	if alive[0].url != fast.URL {
+		t.Fatalf("probeMirrors[0] = %s, want the faster responder %s", alive[0].url, fast.URL)
+	}
+}
+
+func TestProbeMirrorsRejectsMismatchedSize(t *testing.T) {
+	a := newMirrorServer(t, "hello", 0)
+	b := newMirrorServer(t, "a much longer body than the other one", 0)
+
+	d := &Download{}
+
+	alive, err := d.probeMirrors(d.ctx(), []string{a.URL, b.URL})
+	if err != nil {
+		t.Fatalf("probeMirrors: %v", err)
+	}
+
+	if len(alive) != 1 {
+		t.Fatalf("probeMirrors returned %d alive mirrors, want 1 (mismatched Content-Length excluded)", len(alive))
+	}
+}
+
+func TestProbeMirrorsNoneAlive(t *testing.T) {
+	d := &Download{}
+
+	if _, err := d.probeMirrors(d.ctx(), []string{"http://127.0.0.1:0"}); err != ErrNoAliveMirrors {
+		t.Fatalf("probeMirrors with no reachable mirror: err = %v, want ErrNoAliveMirrors", err)
+	}
+}
+
+func TestSortMirrorsByTTFB(t *testing.T) {
+	m := []aliveMirror{
+		{url: "c", ttfb: 30 * time.Millisecond},
+		{url: "a", ttfb: 10 * time.Millisecond},
+		{url: "b", ttfb: 20 * time.Millisecond},
+	}
+
+	sortMirrorsByTTFB(m)
+
+	want := []string{"a", "b", "c"}
+	for i, u := range want {
+		if m[i].url != u {
+			t.Fatalf("sortMirrorsByTTFB[%d] = %s, want %s", i, m[i].url, u)
+		}
+	}
+}
+
+func TestMirrorFetcherRoundRobinsAcrossMirrors(t *testing.T) {
+	a := newMirrorServer(t, "aaaaa", 0)
+	b := newMirrorServer(t, "bbbbb", 0)
+
+	d := &Download{}
+	alive := []aliveMirror{{url: a.URL}, {url: b.URL}}
+	fetch := d.mirrorFetcher(alive)
+
+	seen := map[string]int{}
+	for i := 0; i < 4; i++ {
+		rc, err := fetch(d.ctx(), 0, 5)
+		if err != nil {
+			t.Fatalf("fetch #%d: %v", i, err)
+		}
+		body, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("read #%d: %v", i, err)
+		}
+		seen[string(body)]++
+	}
+
+	if seen["aaaaa"] == 0 || seen["bbbbb"] == 0 {
+		t.Fatalf("mirrorFetcher didn't distribute requests across both mirrors: %v", seen)
+	}
+}
+
+func TestMirrorFetcherFallsBackOnFailure(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(down.Close)
+
+	up := newMirrorServer(t, "ok", 0)
+
+	d := &Download{RetryPolicy: &RetryPolicy{MaxRetries: 3, Wait: time.Millisecond}}
+	alive := []aliveMirror{{url: down.URL}, {url: up.URL}}
+	fetch := d.mirrorFetcher(alive)
+
+	rc, err := fetch(d.ctx(), 0, 2)
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	defer rc.Close()
+
+	body, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(body) != "ok" {
+		t.Fatalf("fetch body = %q, want %q from the surviving mirror", body, "ok")
+	}
+}
+
+func TestMirrorFetcherHonorsMaxRetries(t *testing.T) {
+	var hits int
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(down.Close)
+
+	up := newMirrorServer(t, "ok", 0)
+
+	// MaxRetries: 0 means the first failure is final, even though a
+	// healthy mirror is available: --max-retries=0 must disable mirror
+	// fallback too, not just single-source retries.
+	d := &Download{RetryPolicy: &RetryPolicy{MaxRetries: 0, Wait: time.Millisecond}}
+	alive := []aliveMirror{{url: down.URL}, {url: up.URL}}
+	fetch := d.mirrorFetcher(alive)
+
+	if _, err := fetch(d.ctx(), 0, 2); err == nil {
+		t.Fatal("expected an error with MaxRetries: 0 and the first mirror failing")
+	}
+	if hits != 1 {
+		t.Fatalf("down mirror was hit %d times, want exactly 1 with MaxRetries: 0", hits)
+	}
+}
@@ -0,0 +1,102 @@
+package got
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// tokenBucket is a simple bytes/sec rate limiter shared across all of a
+// download's concurrent chunk readers, so the aggregate throughput (not
+// per-chunk) stays under MaxBytesPerSec.
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64 // bytes/sec
+	capacity float64 // burst ceiling tokens accumulate to
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(bytesPerSec uint64) *tokenBucket {
+	// The bucket must hold at least maxThrottleChunk tokens, or a rate
+	// below that (e.g. --limit-rate=16K) can never accumulate enough to
+	// satisfy a single throttledReader.Read's take(), deadlocking the
+	// download.
+	capacity := float64(bytesPerSec)
+	if capacity < maxThrottleChunk {
+		capacity = maxThrottleChunk
+	}
+
+	return &tokenBucket{
+		rate:     float64(bytesPerSec),
+		capacity: capacity,
+		tokens:   capacity,
+		last:     time.Now(),
+	}
+}
+
+// take blocks until n bytes' worth of tokens are available.
+func (tb *tokenBucket) take(ctx context.Context, n int) error {
+	for {
+		tb.mu.Lock()
+
+		now := time.Now()
+		tb.tokens += now.Sub(tb.last).Seconds() * tb.rate
+		if tb.tokens > tb.capacity {
+			tb.tokens = tb.capacity
+		}
+		tb.last = now
+
+		if tb.tokens >= float64(n) {
+			tb.tokens -= float64(n)
+			tb.mu.Unlock()
+			return nil
+		}
+
+		wait := time.Duration((float64(n) - tb.tokens) / tb.rate * float64(time.Second))
+		tb.mu.Unlock()
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// maxThrottleChunk caps how many bytes a single throttled Read hands
+// back, so the bucket can apply backpressure smoothly instead of in one
+// big burst per underlying Read.
+const maxThrottleChunk = 32 * 1024
+
+// throttledReader rate-limits reads from r against a shared tokenBucket.
+type throttledReader struct {
+	ctx context.Context
+	r   io.Reader
+	tb  *tokenBucket
+}
+
+func (t *throttledReader) Read(p []byte) (int, error) {
+	if len(p) > maxThrottleChunk {
+		p = p[:maxThrottleChunk]
+	}
+
+	n, err := t.r.Read(p)
+	if n > 0 {
+		if werr := t.tb.take(t.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+
+	return n, err
+}
+
+// throttle wraps r so it never yields bytes faster than tb allows. A nil
+// tb (MaxBytesPerSec == 0) disables throttling entirely.
+func throttle(ctx context.Context, r io.Reader, tb *tokenBucket) io.Reader {
+	if tb == nil {
+		return r
+	}
+	return &throttledReader{ctx: ctx, r: r, tb: tb}
+}
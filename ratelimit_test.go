@@ -0,0 +1,63 @@
+package got
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketTakeWithinCapacityDoesNotBlock(t *testing.T) {
+	tb := newTokenBucket(1 << 20) // 1 MiB/s
+
+	start := time.Now()
+	if err := tb.take(context.Background(), 1024); err != nil {
+		t.Fatalf("take: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("take blocked for %s on a request well within capacity", elapsed)
+	}
+}
+
+func TestTokenBucketTakeThrottlesBelowCapacity(t *testing.T) {
+	tb := newTokenBucket(1024) // 1 KiB/s, capacity clamped to maxThrottleChunk
+
+	ctx := context.Background()
+	if err := tb.take(ctx, int(maxThrottleChunk)); err != nil {
+		t.Fatalf("first take (draining the initial burst): %v", err)
+	}
+
+	start := time.Now()
+	if err := tb.take(ctx, 512); err != nil {
+		t.Fatalf("second take: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("take on an exhausted bucket returned after %s, want it to wait for tokens to refill", elapsed)
+	}
+}
+
+func TestTokenBucketTakeRespectsContextCancellation(t *testing.T) {
+	tb := newTokenBucket(1) // effectively never refills enough for a second take
+
+	ctx := context.Background()
+	if err := tb.take(ctx, int(maxThrottleChunk)); err != nil {
+		t.Fatalf("first take: %v", err)
+	}
+
+	cancelCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+
+	if err := tb.take(cancelCtx, int(maxThrottleChunk)); err == nil {
+		t.Fatal("expected take to return an error once its context is canceled")
+	}
+}
+
+func TestThrottleNilBucketIsNoOp(t *testing.T) {
+	r := throttle(context.Background(), strings.NewReader("hello"), nil)
+
+	buf := make([]byte, 5)
+	n, err := r.Read(buf)
+	if err != nil || n != 5 || string(buf) != "hello" {
+		t.Fatalf("throttle with nil tokenBucket altered the read: n=%d err=%v buf=%q", n, err, buf)
+	}
+}
@@ -0,0 +1,123 @@
+package got
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how transient chunk failures are retried:
+// exponential backoff with jitter, bounded by MaxRetries, retrying only
+// transient errors (connection reset, timeouts, 5xx, 408, 429).
+type RetryPolicy struct {
+	// MaxRetries is the number of additional attempts after the first
+	// failure; 0 disables retries.
+	MaxRetries int
+
+	// Wait is the base delay the exponential backoff scales from.
+	Wait time.Duration
+
+	// Timeout, if set, bounds each individual chunk request.
+	Timeout time.Duration
+}
+
+// DefaultRetryPolicy is used when a Download's RetryPolicy is nil.
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 3, Wait: time.Second}
+
+func (d *Download) retryPolicy() RetryPolicy {
+	if d.RetryPolicy != nil {
+		return *d.RetryPolicy
+	}
+	return DefaultRetryPolicy
+}
+
+// httpStatusError wraps a non-2xx HTTP response so callers can tell
+// transient failures (5xx, 408, 429) from permanent ones (404, 403...).
+type httpStatusError struct {
+	StatusCode int
+	RetryAfter time.Duration
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("got: unexpected status %d", e.StatusCode)
+}
+
+func (e *httpStatusError) retryable() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	default:
+		return e.StatusCode >= 500
+	}
+}
+
+// retryAfterDuration parses a Retry-After header (either seconds or an
+// HTTP-date), returning 0 if absent or unparseable.
+func retryAfterDuration(res *http.Response) time.Duration {
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+
+	return 0
+}
+
+func isTransient(err error) bool {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.retryable()
+	}
+
+	// A reset connection is the canonical transient failure (and the
+	// first one RetryPolicy's doc comment names), but it's neither a
+	// net.Error timeout nor io.ErrUnexpectedEOF, so it needs its own
+	// check.
+	if errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func sleepBackoff(ctx context.Context, policy RetryPolicy, attempt int, err error) error {
+	wait := policy.Wait
+	if wait <= 0 {
+		wait = DefaultRetryPolicy.Wait
+	}
+
+	delay := time.Duration(float64(wait) * math.Pow(2, float64(attempt)))
+	delay += time.Duration(rand.Int63n(int64(delay)/2 + 1)) // jitter
+
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		delay = statusErr.RetryAfter
+	}
+
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,101 @@
+package got
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIsTransient(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"5xx status", &httpStatusError{StatusCode: http.StatusBadGateway}, true},
+		{"408 status", &httpStatusError{StatusCode: http.StatusRequestTimeout}, true},
+		{"429 status", &httpStatusError{StatusCode: http.StatusTooManyRequests}, true},
+		{"404 status", &httpStatusError{StatusCode: http.StatusNotFound}, false},
+		{"unexpected EOF", io.ErrUnexpectedEOF, true},
+		{"deadline exceeded", context.DeadlineExceeded, true},
+		{"plain error", errors.New("boom"), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := isTransient(c.err); got != c.want {
+				t.Fatalf("isTransient(%v) = %v, want %v", c.err, got, c.want)
+			}
+		})
+	}
+}
+
+func TestSleepBackoffJitterBounds(t *testing.T) {
+	policy := RetryPolicy{Wait: 10 * time.Millisecond}
+
+	for attempt := 0; attempt < 4; attempt++ {
+		base := time.Duration(float64(policy.Wait) * pow2(attempt))
+		maxDelay := base + base/2 + time.Millisecond // jitter ceiling, plus slack for rounding
+
+		start := time.Now()
+		if err := sleepBackoff(context.Background(), policy, attempt, errors.New("boom")); err != nil {
+			t.Fatalf("sleepBackoff attempt %d: %v", attempt, err)
+		}
+		elapsed := time.Since(start)
+
+		if elapsed < base {
+			t.Fatalf("sleepBackoff attempt %d slept %s, want at least the base delay %s", attempt, elapsed, base)
+		}
+		if elapsed > maxDelay+20*time.Millisecond { // scheduling slack
+			t.Fatalf("sleepBackoff attempt %d slept %s, want at most %s", attempt, elapsed, maxDelay)
+		}
+	}
+}
+
+func pow2(n int) float64 {
+	f := 1.0
+	for i := 0; i < n; i++ {
+		f *= 2
+	}
+	return f
+}
+
+func TestSleepBackoffHonorsRetryAfter(t *testing.T) {
+	policy := RetryPolicy{Wait: time.Second}
+	err := &httpStatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 10 * time.Millisecond}
+
+	start := time.Now()
+	if serr := sleepBackoff(context.Background(), policy, 5, err); serr != nil {
+		t.Fatalf("sleepBackoff: %v", serr)
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Fatalf("sleepBackoff took %s, want it to honor the short Retry-After instead of the exponential backoff", elapsed)
+	}
+}
+
+func TestRetryAfterDurationSeconds(t *testing.T) {
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	if got := retryAfterDuration(res); got != 2*time.Second {
+		t.Fatalf("retryAfterDuration = %s, want 2s", got)
+	}
+}
+
+func TestRetryAfterDurationAbsent(t *testing.T) {
+	res := &http.Response{Header: http.Header{}}
+	if got := retryAfterDuration(res); got != 0 {
+		t.Fatalf("retryAfterDuration with no header = %s, want 0", got)
+	}
+}
+
+func TestSleepBackoffRespectsContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	policy := RetryPolicy{Wait: time.Hour}
+	if err := sleepBackoff(ctx, policy, 0, errors.New("boom")); err == nil {
+		t.Fatal("expected sleepBackoff to return an error once its context is canceled")
+	}
+}
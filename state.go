@@ -0,0 +1,58 @@
+package got
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// chunkState is the persisted status of a single chunk, used to resume
+// an interrupted download.
+type chunkState struct {
+	Offset int64 `json:"offset"`
+	Length int64 `json:"length"`
+	Done   bool  `json:"done"`
+}
+
+// downloadState is the sidecar ".got" file written next to the
+// destination so a killed got process leaves behind resumable state
+// instead of a truncated, unrecoverable file.
+type downloadState struct {
+	URL          string       `json:"url"`
+	ETag         string       `json:"etag,omitempty"`
+	LastModified string       `json:"last_modified,omitempty"`
+	Size         uint64       `json:"size"`
+	Chunks       []chunkState `json:"chunks"`
+}
+
+func stateFilename(dest string) string {
+	return dest + ".got"
+}
+
+func loadState(dest string) (*downloadState, error) {
+	f, err := os.Open(stateFilename(dest))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var st downloadState
+	if err := json.NewDecoder(f).Decode(&st); err != nil {
+		return nil, err
+	}
+
+	return &st, nil
+}
+
+func (st *downloadState) save(dest string) error {
+	f, err := os.Create(stateFilename(dest))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewEncoder(f).Encode(st)
+}
+
+func removeState(dest string) {
+	os.Remove(stateFilename(dest))
+}
@@ -0,0 +1,153 @@
+package got
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStateSaveLoadRoundTrip(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "file.bin")
+
+	want := &downloadState{
+		URL:          "https://example.com/file.bin",
+		ETag:         `"abc123"`,
+		LastModified: "Wed, 21 Oct 2015 07:28:00 GMT",
+		Size:         1024,
+		Chunks: []chunkState{
+			{Offset: 0, Length: 512, Done: true},
+			{Offset: 512, Length: 512, Done: false},
+		},
+	}
+
+	if err := want.save(dest); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	got, err := loadState(dest)
+	if err != nil {
+		t.Fatalf("loadState: %v", err)
+	}
+
+	if got.URL != want.URL || got.ETag != want.ETag || got.LastModified != want.LastModified || got.Size != want.Size {
+		t.Fatalf("loadState = %+v, want %+v", got, want)
+	}
+
+	if len(got.Chunks) != len(want.Chunks) {
+		t.Fatalf("got %d chunks, want %d", len(got.Chunks), len(want.Chunks))
+	}
+	for i, c := range got.Chunks {
+		if c != want.Chunks[i] {
+			t.Fatalf("chunk %d = %+v, want %+v", i, c, want.Chunks[i])
+		}
+	}
+}
+
+func TestLoadStateMissing(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "file.bin")
+
+	if _, err := loadState(dest); !os.IsNotExist(err) {
+		t.Fatalf("loadState on missing sidecar: got err %v, want os.IsNotExist", err)
+	}
+}
+
+func TestRemoveState(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "file.bin")
+
+	if err := (&downloadState{URL: dest}).save(dest); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	removeState(dest)
+
+	if _, err := os.Stat(stateFilename(dest)); !os.IsNotExist(err) {
+		t.Fatalf("state file still exists after removeState: err = %v", err)
+	}
+}
+
+func TestResolveChunksFresh(t *testing.T) {
+	d := &Download{size: 100, chunkable: true, Concurrency: 4}
+
+	chunks, resumed, err := d.resolveChunks()
+	if err != nil {
+		t.Fatalf("resolveChunks: %v", err)
+	}
+	if resumed {
+		t.Fatal("resolveChunks reported resumed with no sidecar state file")
+	}
+	if len(chunks) == 0 {
+		t.Fatal("resolveChunks returned no chunks")
+	}
+}
+
+func TestResolveChunksResume(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "file.bin")
+
+	st := &downloadState{
+		URL:  "https://example.com/file.bin",
+		Size: 100,
+		Chunks: []chunkState{
+			{Offset: 0, Length: 50, Done: true},
+			{Offset: 50, Length: 50, Done: false},
+		},
+	}
+	if err := st.save(dest); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	d := &Download{
+		URL:       "https://example.com/file.bin",
+		Resume:    true,
+		chunkable: true,
+		size:      100,
+		filename:  dest,
+	}
+
+	chunks, resumed, err := d.resolveChunks()
+	if err != nil {
+		t.Fatalf("resolveChunks: %v", err)
+	}
+	if !resumed {
+		t.Fatal("resolveChunks didn't report resumed with matching sidecar state")
+	}
+	if len(chunks) != 2 || !chunks[0].done || chunks[1].done {
+		t.Fatalf("resolveChunks chunks = %+v, want first done, second not", chunks)
+	}
+}
+
+func TestResolveChunksResumeResourceChanged(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "file.bin")
+
+	st := &downloadState{URL: "https://example.com/file.bin", Size: 100}
+	if err := st.save(dest); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	d := &Download{
+		URL:       "https://example.com/file.bin",
+		Resume:    true,
+		chunkable: true,
+		size:      200, // size changed since the sidecar was written
+		filename:  dest,
+	}
+
+	if _, _, err := d.resolveChunks(); err == nil {
+		t.Fatal("expected an error when the resource changed since the sidecar was written")
+	}
+}
+
+func TestSplitChunks(t *testing.T) {
+	chunks := splitChunks(100, 30, 4)
+
+	var total int64
+	for i, c := range chunks {
+		if c.offset != total {
+			t.Fatalf("chunk %d offset = %d, want %d", i, c.offset, total)
+		}
+		total += c.length
+	}
+
+	if total != 100 {
+		t.Fatalf("chunks cover %d bytes, want 100", total)
+	}
+}